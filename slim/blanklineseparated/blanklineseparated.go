@@ -0,0 +1,84 @@
+// Package blanklineseparated provides a slim.Framing for records separated by a blank line.
+package blanklineseparated
+
+import "bytes"
+
+// Framing terminates each record with a blank line, i.e. two consecutive newlines.
+//
+// A blank line can also appear inside a single record, between a commit's description and its
+// body, or between its body and footers - that looks identical to the separator itself. Split
+// tells the two apart by only treating a blank line as a record boundary when the line right
+// after it looks like the start of a new Conventional Commit header (a type, an optional
+// (scope), an optional '!', then ':'); otherwise it treats the blank line as part of the current
+// record and keeps looking for the next one.
+type Framing struct{}
+
+// Split reports the next blank-line-terminated record in data, see slim.Framing.
+func (Framing) Split(data []byte, atEOF bool) (advance int, record []byte, ok bool) {
+	const delim = "\n\n"
+
+	search := 0
+	for {
+		idx := bytes.Index(data[search:], []byte(delim))
+		if idx < 0 {
+			break
+		}
+		idx += search
+
+		rest := data[idx+len(delim):]
+		if len(rest) == 0 {
+			if !atEOF {
+				// This could be a record boundary, but there isn't enough buffered yet to tell
+				// what, if anything, follows it.
+				break
+			}
+			return idx + len(delim), data[:idx], true
+		}
+		if looksLikeHeader(rest) {
+			return idx + len(delim), data[:idx], true
+		}
+
+		// This blank line separates a description from its body (or a body from its footers),
+		// not one record from the next; keep looking.
+		search = idx + len(delim)
+	}
+
+	if atEOF && len(data) > 0 {
+		return len(data), data, true
+	}
+
+	return 0, nil, false
+}
+
+// looksLikeHeader reports whether line begins with a Conventional Commit header: one or more
+// type letters, an optional (scope), an optional '!', then ':'.
+func looksLikeHeader(line []byte) bool {
+	i := 0
+	if i >= len(line) || !isTypeByte(line[i]) {
+		return false
+	}
+	for i < len(line) && isTypeByte(line[i]) {
+		i++
+	}
+
+	if i < len(line) && line[i] == '(' {
+		i++
+		for i < len(line) && line[i] != ')' {
+			i++
+		}
+		if i >= len(line) {
+			return false
+		}
+		i++
+	}
+
+	if i < len(line) && line[i] == '!' {
+		i++
+	}
+
+	return i < len(line) && line[i] == ':'
+}
+
+func isTypeByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
@@ -0,0 +1,78 @@
+package slim
+
+import (
+	"unicode/utf8"
+
+	xtextencoding "golang.org/x/text/encoding"
+)
+
+// Encoding selects how the machine treats the bytes it captures for the description and body.
+// The scope is not covered: its bytes are captured as-is whatever the Encoding.
+type Encoding int
+
+const (
+	// EncodingUTF8 is the default: description/body bytes are validated as UTF-8 and rejected,
+	// with ErrInvalidUTF8, if they aren't.
+	EncodingUTF8 Encoding = iota
+	// EncodingRaw passes description/body bytes through unvalidated and untranscoded, the
+	// behavior the machine always had before WithEncoding existed.
+	EncodingRaw
+	// EncodingAny transcodes description/body bytes from the Encoding given to WithDecoder into
+	// UTF-8 before they are captured, for commits authored in a legacy locale (Shift-JIS, GBK, ...).
+	EncodingAny
+)
+
+// WithEncoding tells the parser how to treat description/body bytes. It defaults to EncodingUTF8.
+func (m *machine) WithEncoding(enc Encoding) {
+	m.encoding = enc
+}
+
+// WithDecoder supplies the decoder EncodingAny transcodes description/body bytes through. It has
+// no effect unless the machine's Encoding is EncodingAny.
+func (m *machine) WithDecoder(dec xtextencoding.Encoding) {
+	m.decoder = dec
+}
+
+// convertText applies the handler's configured Encoding to b, returning the bytes to store and
+// an error if b is rejected (EncodingUTF8) or fails to transcode (EncodingAny).
+func (h *defaultHandler) convertText(b []byte) ([]byte, error) {
+	switch h.encoding {
+	case EncodingRaw:
+		return b, nil
+	case EncodingAny:
+		if h.decoder == nil {
+			return b, nil
+		}
+		decoded, err := h.decoder.NewDecoder().Bytes(b)
+		if err != nil {
+			return b, err
+		}
+		return decoded, nil
+	default: // EncodingUTF8
+		if !utf8.Valid(b) {
+			offset := h.offset + firstInvalidUTF8(b)
+			line, column, snippet := lineAndColumn(h.data, offset)
+			return b, &ParseError{
+				Kind:    KindInvalidUTF8,
+				Offset:  offset,
+				Line:    line,
+				Column:  column,
+				Snippet: snippet,
+				Message: ErrInvalidUTF8,
+			}
+		}
+		return b, nil
+	}
+}
+
+// firstInvalidUTF8 returns the byte offset of the first invalid UTF-8 sequence in b.
+func firstInvalidUTF8(b []byte) int {
+	for i := 0; i < len(b); {
+		r, size := utf8.DecodeRune(b[i:])
+		if r == utf8.RuneError && size <= 1 {
+			return i
+		}
+		i += size
+	}
+	return len(b)
+}
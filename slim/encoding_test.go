@@ -0,0 +1,106 @@
+package slim
+
+import (
+	"testing"
+
+	"github.com/leodido/go-conventionalcommits"
+	"golang.org/x/text/encoding/charmap"
+)
+
+func TestEncodingUTF8RejectsInvalidUTF8(t *testing.T) {
+	m := &machine{}
+	// 0xff is never valid UTF-8, on its own or as a continuation byte. It sits at absolute offset
+	// 6, right after the 6-byte "feat: " prefix, not at offset 0 within the description alone.
+	input := append([]byte("feat: "), 0xff)
+
+	_, err := m.Parse(input)
+	if err == nil {
+		t.Fatal("Parse returned no error for an invalid UTF-8 description")
+	}
+
+	pe, ok := err.(*ParseError)
+	if !ok {
+		t.Fatalf("error %v is not a *ParseError", err)
+	}
+	if pe.Kind != KindInvalidUTF8 {
+		t.Errorf("Kind = %v, want %v", pe.Kind, KindInvalidUTF8)
+	}
+	if pe.Offset != 6 {
+		t.Errorf("Offset = %d, want %d (relative to the whole input, not the description alone)", pe.Offset, 6)
+	}
+	if pe.Line != 1 {
+		t.Errorf("Line = %d, want 1", pe.Line)
+	}
+	if pe.Column != 6 {
+		t.Errorf("Column = %d, want %d", pe.Column, 6)
+	}
+	if pe.Snippet != string(input) {
+		t.Errorf("Snippet = %q, want %q", pe.Snippet, string(input))
+	}
+}
+
+func TestEncodingUTF8RejectsInvalidUTF8InBody(t *testing.T) {
+	m := &machine{}
+	m.WithBestEffort()
+
+	// The invalid byte sits in the body, on the second line, well past the description.
+	input := append([]byte("feat: add streaming support\n\nfirst line\nsecond line caf"), 0xe9)
+
+	_, err := m.Parse(input)
+	if err == nil {
+		t.Fatal("Parse returned no error for an invalid UTF-8 body")
+	}
+
+	pe, ok := err.(*ParseError)
+	if !ok {
+		t.Fatalf("error %v is not a *ParseError", err)
+	}
+	if pe.Kind != KindInvalidUTF8 {
+		t.Errorf("Kind = %v, want %v", pe.Kind, KindInvalidUTF8)
+	}
+	wantOffset := len(input) - 1
+	if pe.Offset != wantOffset {
+		t.Errorf("Offset = %d, want %d", pe.Offset, wantOffset)
+	}
+	if pe.Line != 4 {
+		t.Errorf("Line = %d, want 4", pe.Line)
+	}
+	if pe.Snippet != "second line caf\xe9" {
+		t.Errorf("Snippet = %q, want %q", pe.Snippet, "second line caf\xe9")
+	}
+}
+
+func TestEncodingRawPassesInvalidUTF8Through(t *testing.T) {
+	m := &machine{}
+	m.WithEncoding(EncodingRaw)
+	input := append([]byte("feat: "), 0xff)
+
+	msg, err := m.Parse(input)
+	if err != nil {
+		t.Fatalf("Parse returned unexpected error: %v", err)
+	}
+
+	cc := msg.(*conventionalcommits.ConventionalCommit)
+	if cc.Description != string(append([]byte{}, 0xff)) {
+		t.Errorf("Description = %q, want the raw byte passed through untouched", cc.Description)
+	}
+}
+
+func TestEncodingAnyTranscodesThroughDecoder(t *testing.T) {
+	m := &machine{}
+	m.WithEncoding(EncodingAny)
+	m.WithDecoder(charmap.ISO8859_1)
+
+	// 0xe9 is "é" in ISO-8859-1, not valid UTF-8 on its own.
+	input := append([]byte("feat: caf"), 0xe9)
+
+	msg, err := m.Parse(input)
+	if err != nil {
+		t.Fatalf("Parse returned unexpected error: %v", err)
+	}
+
+	cc := msg.(*conventionalcommits.ConventionalCommit)
+	if cc.Description != "café" {
+		t.Errorf("Description = %q, want %q", cc.Description, "café")
+	}
+}
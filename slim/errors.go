@@ -0,0 +1,184 @@
+package slim
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ColumnPositionTemplate is the template used to communicate the column where errors occur.
+var ColumnPositionTemplate = ": col=%02d"
+
+const (
+	// ErrType represents an error in the type part of the commit message.
+	ErrType = "illegal '%s' character in commit message type"
+	// ErrColon is the error message that communicate that the mandatory colon after the type part of the commit message is missing.
+	ErrColon = "expecting colon (':') character, got '%s' character"
+	// ErrTypeIncomplete represents an error in the type part of the commit message.
+	ErrTypeIncomplete = "incomplete commit message type after '%s' character"
+	// ErrMalformedScope represents an error about illegal characters into the the scope part of the commit message.
+	ErrMalformedScope = "illegal '%s' character in scope"
+	// ErrEmpty represents an error when the input is empty.
+	ErrEmpty = "empty input"
+	// ErrEarly represents an error when the input makes the machine exit too early.
+	ErrEarly = "early exit after '%s' character"
+	// ErrDescriptionInit tells the user that before of the description part a whitespace is mandatory.
+	ErrDescriptionInit = "expecting at least one white-space (' ') character, got '%s' character"
+	// ErrDescription tells the user that after the whitespace is mandatory a description.
+	ErrDescription = "expecting a description text (without newlines) after '%s' character"
+	// ErrNewline communicates an illegal newline to the user.
+	ErrNewline = "illegal newline"
+	// ErrMissingBlankLineAtBodyBegin tells the user that the body must start with a blank line.
+	ErrMissingBlankLineAtBodyBegin = "body must begin with a blank line"
+	// ErrMissingBlankLineAtFooterBegin tells the user that the footer must start with a blank line.
+	ErrMissingBlankLineAtFooterBegin = "footer must begin with a blank line"
+	// ErrInvalidUTF8 tells the user that the description or body contains invalid UTF-8.
+	ErrInvalidUTF8 = "invalid UTF-8 encoding"
+)
+
+// ErrorKind classifies which production of the Conventional Commits grammar a ParseError
+// occurred in, so that callers can react to specific failures programmatically instead of
+// string-matching Error().
+type ErrorKind int
+
+const (
+	// KindType is returned when the commit message type is illegal.
+	KindType ErrorKind = iota
+	// KindColon is returned when the mandatory colon after the type (and optional scope) is missing.
+	KindColon
+	// KindTypeIncomplete is returned when the input ends in the middle of the type production.
+	KindTypeIncomplete
+	// KindMalformedScope is returned when the scope contains an illegal character.
+	KindMalformedScope
+	// KindDescriptionInit is returned when the mandatory white-space before the description is missing.
+	KindDescriptionInit
+	// KindDescription is returned when the description is missing or malformed.
+	KindDescription
+	// KindNewline is returned when a newline appears where it is illegal.
+	KindNewline
+	// KindMissingBlankLineAtBodyBegin is returned when the body does not start with a blank line.
+	KindMissingBlankLineAtBodyBegin
+	// KindMissingBlankLineAtFooterBegin is returned when the footer does not start with a blank line.
+	KindMissingBlankLineAtFooterBegin
+	// KindEmpty is returned when the input is empty.
+	KindEmpty
+	// KindEarly is returned when the input ends before the machine can reach a final state.
+	KindEarly
+	// KindInvalidUTF8 is returned when the description or body contains invalid UTF-8.
+	KindInvalidUTF8
+)
+
+// kindOf maps an error message template to the ErrorKind it represents.
+var kindOf = map[string]ErrorKind{
+	ErrType:                          KindType,
+	ErrColon:                         KindColon,
+	ErrTypeIncomplete:                KindTypeIncomplete,
+	ErrMalformedScope:                KindMalformedScope,
+	ErrEmpty:                         KindEmpty,
+	ErrEarly:                         KindEarly,
+	ErrDescriptionInit:               KindDescriptionInit,
+	ErrDescription:                   KindDescription,
+	ErrNewline:                       KindNewline,
+	ErrMissingBlankLineAtBodyBegin:   KindMissingBlankLineAtBodyBegin,
+	ErrMissingBlankLineAtFooterBegin: KindMissingBlankLineAtFooterBegin,
+}
+
+// ParseError is the structured error the machine returns when it fails to parse a commit
+// message. Its Error() reproduces the same formatted string the machine has always returned, so
+// it is a drop-in replacement for callers that were matching on error text.
+type ParseError struct {
+	// Kind classifies which grammar production failed.
+	Kind ErrorKind
+	// Offset is the 0-based byte offset into the whole input where the error occurred.
+	Offset int
+	// Line is the 1-based line number Offset falls on, counting newlines seen before it.
+	Line int
+	// Column is the 0-based byte offset into Line where the error occurred.
+	Column int
+	// Snippet is the line of input the error occurred on, without its trailing newline.
+	Snippet string
+	// Expected holds the character(s) the grammar expected in place of Offender, when known.
+	Expected []rune
+	// Offender is the offending byte, or 0 if the error isn't tied to a specific one.
+	Offender byte
+	// Message is the human-readable description of the error, without the column suffix.
+	Message string
+}
+
+func (e *ParseError) Error() string {
+	if strings.Contains(e.Message, "%s") {
+		return fmt.Sprintf(e.Message+ColumnPositionTemplate, string(e.Offender), e.Offset)
+	}
+	return fmt.Sprintf(e.Message+ColumnPositionTemplate, e.Offset)
+}
+
+// Is lets errors.Is match ParseError values by Kind alone, e.g.:
+//
+//	errors.Is(err, &slim.ParseError{Kind: slim.KindType})
+func (e *ParseError) Is(target error) bool {
+	t, ok := target.(*ParseError)
+	if !ok {
+		return false
+	}
+	return e.Kind == t.Kind
+}
+
+// expectedOf maps an error message template to the character(s) the grammar expected, for the
+// templates where that set is fixed and small; templates not present here leave Expected nil.
+var expectedOf = map[string][]rune{
+	ErrColon:           {':'},
+	ErrDescriptionInit: {' '},
+}
+
+// MultiError collects every ParseError a best-effort parse encountered.
+//
+// The slim machine halts at its first parse error within a single TypeConfig attempt (it has no
+// recovery production to skip past one and keep going), so a call made without a fallback chain
+// holds at most one ParseError here. A call made with WithFallbackChain holds up to one per
+// config tried, since machine.Errors accumulates across the whole chain rather than resetting
+// between attempts.
+type MultiError struct {
+	Errors []*ParseError
+}
+
+func (e *MultiError) Error() string {
+	if len(e.Errors) == 0 {
+		return ""
+	}
+	s := e.Errors[0].Error()
+	for _, pe := range e.Errors[1:] {
+		s += "; " + pe.Error()
+	}
+	return s
+}
+
+// lineAndColumn reports the 1-based line number and 0-based column offset corresponds to the
+// byte offset pos within data, along with the (newline-free) line of text it falls on.
+//
+// It is computed lazily, by scanning backward and forward from pos, rather than threading a
+// running line/column counter through the Ragel-generated state transitions: errors are rare
+// relative to bytes scanned, so paying this cost only when one is actually emitted is cheaper
+// overall than paying it on every byte the machine consumes.
+func lineAndColumn(data []byte, pos int) (line, column int, snippet string) {
+	if pos > len(data) {
+		pos = len(data)
+	}
+
+	lineStart := 0
+	line = 1
+	for i := 0; i < pos; i++ {
+		if data[i] == '\n' {
+			line++
+			lineStart = i + 1
+		}
+	}
+
+	lineEnd := len(data)
+	for i := pos; i < len(data); i++ {
+		if data[i] == '\n' {
+			lineEnd = i
+			break
+		}
+	}
+
+	return line, pos - lineStart, string(data[lineStart:lineEnd])
+}
@@ -0,0 +1,14 @@
+package slim
+
+import "github.com/leodido/go-conventionalcommits"
+
+// WithFallback tells the parser to retry, in order, under each of the given TypeConfig if the
+// primary one fails, analogous to WithBestEffort. It is variadic sugar over WithFallbackChain, for
+// callers who think of this as "try these configurations in order" rather than building a slice.
+//
+// A conventionalcommits.Spec does not exist in this module's dependency, and would not help here
+// regardless: the slim machine's grammar does not branch on it, only on TypeConfig, which is what
+// WithFallbackChain (and this) vary between attempts. MatchedTypeConfig reports which one matched.
+func (m *machine) WithFallback(chain ...conventionalcommits.TypeConfig) {
+	m.WithFallbackChain(chain)
+}
@@ -0,0 +1,94 @@
+package slim
+
+import (
+	"testing"
+
+	"github.com/leodido/go-conventionalcommits"
+)
+
+func TestFallbackChainMatchesLooserConfig(t *testing.T) {
+	m := &machine{}
+	m.WithFallbackChain([]conventionalcommits.TypeConfig{
+		conventionalcommits.TypesConventional,
+		conventionalcommits.TypesFalco,
+	})
+
+	// "new" is only a valid type under TypesFalco, not TypesConventional.
+	msg, err := m.Parse([]byte("new: support falco-style types"))
+	if err != nil {
+		t.Fatalf("Parse returned unexpected error: %v", err)
+	}
+
+	cc := msg.(*conventionalcommits.ConventionalCommit)
+	if cc.Type != "new" {
+		t.Errorf("Type = %q, want %q", cc.Type, "new")
+	}
+	if got := m.MatchedTypeConfig(); got != conventionalcommits.TypesFalco {
+		t.Errorf("MatchedTypeConfig() = %v, want %v", got, conventionalcommits.TypesFalco)
+	}
+}
+
+// trackingHandler is a Handler that records how many times ParseWithHandler resets it between
+// fallback chain attempts, without depending on which particular FSM states a given input reaches.
+type trackingHandler struct {
+	resets int
+}
+
+func (h *trackingHandler) SetType([]byte) error           { return nil }
+func (h *trackingHandler) SetScope([]byte)                {}
+func (h *trackingHandler) SetBreaking()                   {}
+func (h *trackingHandler) SetDescription([]byte)          {}
+func (h *trackingHandler) AddBodyLine([]byte)             {}
+func (h *trackingHandler) AddFooter([]byte, []byte, bool) {}
+func (h *trackingHandler) reset()                         { h.resets++ }
+
+func TestParseWithHandlerResetsBetweenFallbackAttempts(t *testing.T) {
+	m := &machine{}
+	m.WithFallbackChain([]conventionalcommits.TypeConfig{
+		conventionalcommits.TypesConventional,
+		conventionalcommits.TypesFalco,
+		conventionalcommits.TypesMinimal,
+	})
+	h := &trackingHandler{}
+
+	// "nonsense" isn't a valid type under any of the three configs, so every attempt in the chain
+	// runs. What matters here isn't the result, it's that the handler gets reset before every
+	// attempt after the first.
+	_ = m.ParseWithHandler([]byte("nonsense: description"), h)
+
+	if h.resets != 2 {
+		t.Errorf("resets = %d, want 2 (once before each retry after the first attempt)", h.resets)
+	}
+}
+
+func TestMatchedTypeConfigFallsBackToWithTypes(t *testing.T) {
+	m := &machine{}
+	m.WithTypes(conventionalcommits.TypesFalco)
+
+	if got := m.MatchedTypeConfig(); got != conventionalcommits.TypesFalco {
+		t.Errorf("MatchedTypeConfig() before any Parse = %v, want %v", got, conventionalcommits.TypesFalco)
+	}
+
+	if _, err := m.Parse([]byte("not a valid commit")); err == nil {
+		t.Fatal("Parse returned no error for malformed input")
+	}
+	if got := m.MatchedTypeConfig(); got != conventionalcommits.TypesFalco {
+		t.Errorf("MatchedTypeConfig() after a failed Parse = %v, want %v (the WithTypes fallback)", got, conventionalcommits.TypesFalco)
+	}
+}
+
+func TestWithFallbackIsSugarOverWithFallbackChain(t *testing.T) {
+	m := &machine{}
+	m.WithFallback(conventionalcommits.TypesConventional, conventionalcommits.TypesFalco)
+
+	msg, err := m.Parse([]byte("new: support falco-style types"))
+	if err != nil {
+		t.Fatalf("Parse returned unexpected error: %v", err)
+	}
+	if got := m.MatchedTypeConfig(); got != conventionalcommits.TypesFalco {
+		t.Errorf("MatchedTypeConfig() = %v, want %v", got, conventionalcommits.TypesFalco)
+	}
+	if msg.(*conventionalcommits.ConventionalCommit).Type != "new" {
+		t.Errorf("Type = %q, want %q", msg.(*conventionalcommits.ConventionalCommit).Type, "new")
+	}
+}
@@ -0,0 +1,103 @@
+package slim
+
+import xtextencoding "golang.org/x/text/encoding"
+
+// Handler receives callbacks as the machine walks through the states of a Conventional Commit
+// message, SAX-style, instead of having the machine build a Message on the caller's behalf.
+//
+// Implementations only need to care about the pieces of the message they are interested in; the
+// machine calls AddFooter once per recognized footer, in the order footers appear, but the slim
+// machine never parses footers so implementations of this interface never receive that call.
+type Handler interface {
+	// SetType is called with the raw (un-lowercased) commit type once it has been fully read. If it
+	// returns a non-nil error, the machine stops immediately: SetScope, SetBreaking,
+	// SetDescription, AddBodyLine, and AddFooter are never called for this attempt. Without a
+	// fallback chain, that error is what ParseWithHandler (and Parse) returns; with one, the
+	// attempt counts as failed and the next TypeConfig of the chain is tried.
+	SetType(t []byte) error
+	// SetScope is called with the raw scope, if any, once it has been fully read.
+	SetScope(s []byte)
+	// SetBreaking is called when the commit message communicates a breaking change via the `!` marker.
+	SetBreaking()
+	// SetDescription is called with the commit description once it has been fully read.
+	SetDescription(d []byte)
+	// AddBodyLine is called with (a chunk of) the commit body.
+	AddBodyLine(l []byte)
+	// AddFooter is called once per footer token, with isBreaking set when the footer key is a
+	// breaking change marker.
+	AddFooter(key, value []byte, isBreaking bool)
+}
+
+// resetter is implemented by handlers that need to discard state between retries of a
+// WithFallbackChain attempt. ParseWithHandler checks for it with a type assertion rather than
+// requiring it on Handler, so implementations that never run under a fallback chain, or that have
+// nothing to discard, are unaffected.
+type resetter interface {
+	reset()
+}
+
+// positioner is implemented by handlers that need to know where, in the whole input, the next
+// SetDescription or AddBodyLine bytes start. The machine calls setOffset with the absolute byte
+// offset right before each such call, via a type assertion rather than requiring it on Handler, so
+// implementations that have no use for it are unaffected.
+type positioner interface {
+	setOffset(offset int)
+}
+
+// defaultHandler adapts a Handler onto a conventionalCommit so that Parse can be implemented on
+// top of ParseWithHandler.
+type defaultHandler struct {
+	output   *conventionalCommit
+	data     []byte
+	offset   int
+	encoding Encoding
+	decoder  xtextencoding.Encoding
+	err      error
+}
+
+// setOffset records where, in the whole input, the next SetDescription or AddBodyLine bytes
+// start, so convertText can report ParseError positions relative to the whole input rather than
+// to those bytes alone.
+func (h *defaultHandler) setOffset(offset int) {
+	h.offset = offset
+}
+
+// reset discards everything a previous, failed fallback chain attempt wrote to h.output and h.err,
+// so the next attempt starts from a clean conventionalCommit.
+func (h *defaultHandler) reset() {
+	*h.output = conventionalCommit{}
+	h.err = nil
+}
+
+func (h *defaultHandler) SetType(t []byte) error {
+	h.output._type = string(t)
+	return nil
+}
+
+func (h *defaultHandler) SetScope(s []byte) {
+	h.output.scope = string(s)
+}
+
+func (h *defaultHandler) SetBreaking() {
+	h.output.exclamation = true
+}
+
+func (h *defaultHandler) SetDescription(d []byte) {
+	d, err := h.convertText(d)
+	if err != nil && h.err == nil {
+		h.err = err
+	}
+	h.output.descr = string(d)
+}
+
+func (h *defaultHandler) AddBodyLine(l []byte) {
+	l, err := h.convertText(l)
+	if err != nil && h.err == nil {
+		h.err = err
+	}
+	h.output.body += string(l)
+}
+
+func (h *defaultHandler) AddFooter(key, value []byte, isBreaking bool) {
+	// The slim machine does not recognize footers, so this is never called.
+}
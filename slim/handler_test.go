@@ -0,0 +1,62 @@
+package slim
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/leodido/go-conventionalcommits"
+)
+
+// rejectingHandler rejects any commit whose type is in reject, before its description is ever read.
+type rejectingHandler struct {
+	reject       map[string]bool
+	descriptions []string
+}
+
+var errRejectedType = errors.New("rejected commit type")
+
+func (h *rejectingHandler) SetType(t []byte) error {
+	if h.reject[string(t)] {
+		return errRejectedType
+	}
+	return nil
+}
+
+func (h *rejectingHandler) SetScope([]byte)                {}
+func (h *rejectingHandler) SetBreaking()                   {}
+func (h *rejectingHandler) AddBodyLine([]byte)             {}
+func (h *rejectingHandler) AddFooter([]byte, []byte, bool) {}
+
+func (h *rejectingHandler) SetDescription(d []byte) {
+	h.descriptions = append(h.descriptions, string(d))
+}
+
+func TestParseWithHandlerStopsBeforeDescriptionWhenSetTypeRejects(t *testing.T) {
+	m := &machine{}
+	m.WithTypes(conventionalcommits.TypesConventional)
+	h := &rejectingHandler{reject: map[string]bool{"docs": true}}
+
+	err := m.ParseWithHandler([]byte("docs: update README"), h)
+
+	if !errors.Is(err, errRejectedType) {
+		t.Fatalf("ParseWithHandler() error = %v, want errRejectedType", err)
+	}
+	if len(h.descriptions) != 0 {
+		t.Fatalf("SetDescription was called despite SetType rejecting the commit: %v", h.descriptions)
+	}
+}
+
+func TestParseWithHandlerContinuesWhenSetTypeAccepts(t *testing.T) {
+	m := &machine{}
+	m.WithTypes(conventionalcommits.TypesConventional)
+	h := &rejectingHandler{reject: map[string]bool{"docs": true}}
+
+	err := m.ParseWithHandler([]byte("feat: add streaming support"), h)
+
+	if err != nil {
+		t.Fatalf("ParseWithHandler returned unexpected error: %v", err)
+	}
+	if len(h.descriptions) != 1 || h.descriptions[0] != "add streaming support" {
+		t.Fatalf("descriptions = %v, want [%q]", h.descriptions, "add streaming support")
+	}
+}
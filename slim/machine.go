@@ -1,38 +1,9 @@
 package slim
 
 import (
-	"fmt"
-
 	"github.com/leodido/go-conventionalcommits"
 	"github.com/sirupsen/logrus"
-)
-
-// ColumnPositionTemplate is the template used to communicate the column where errors occur.
-var ColumnPositionTemplate = ": col=%02d"
-
-const (
-	// ErrType represents an error in the type part of the commit message.
-	ErrType = "illegal '%s' character in commit message type"
-	// ErrColon is the error message that communicate that the mandatory colon after the type part of the commit message is missing.
-	ErrColon = "expecting colon (':') character, got '%s' character"
-	// ErrTypeIncomplete represents an error in the type part of the commit message.
-	ErrTypeIncomplete = "incomplete commit message type after '%s' character"
-	// ErrMalformedScope represents an error about illegal characters into the the scope part of the commit message.
-	ErrMalformedScope = "illegal '%s' character in scope"
-	// ErrEmpty represents an error when the input is empty.
-	ErrEmpty = "empty input"
-	// ErrEarly represents an error when the input makes the machine exit too early.
-	ErrEarly = "early exit after '%s' character"
-	// ErrDescriptionInit tells the user that before of the description part a whitespace is mandatory.
-	ErrDescriptionInit = "expecting at least one white-space (' ') character, got '%s' character"
-	// ErrDescription tells the user that after the whitespace is mandatory a description.
-	ErrDescription = "expecting a description text (without newlines) after '%s' character"
-	// ErrNewline communicates an illegal newline to the user.
-	ErrNewline = "illegal newline"
-	// ErrMissingBlankLineAtBodyBegin tells the user that the body must start with a blank line.
-	ErrMissingBlankLineAtBodyBegin = "body must begin with a blank line"
-	// ErrMissingBlankLineAtFooterBegin tells the user that the footer must start with a blank line.
-	ErrMissingBlankLineAtFooterBegin = "footer must begin with a blank line"
+	xtextencoding "golang.org/x/text/encoding"
 )
 
 const start int = 1
@@ -43,14 +14,20 @@ const enConventionalTypesMain int = 14
 const enFalcoTypesMain int = 55
 
 type machine struct {
-	data       []byte
-	cs         int
-	p, pe, eof int
-	pb         int
-	err        error
-	bestEffort bool
-	typeConfig conventionalcommits.TypeConfig
-	logger     *logrus.Logger
+	data            []byte
+	cs              int
+	p, pe, eof      int
+	pb              int
+	err             error
+	errs            []*ParseError
+	bestEffort      bool
+	typeConfig      conventionalcommits.TypeConfig
+	fallbackChain   []conventionalcommits.TypeConfig
+	matchedTypeConf conventionalcommits.TypeConfig
+	hasMatched      bool
+	encoding        Encoding
+	decoder         xtextencoding.Encoding
+	logger          *logrus.Logger
 }
 
 func (m *machine) text() []byte {
@@ -67,8 +44,21 @@ func (m *machine) emitInfo(s string, args ...interface{}) {
 	}
 }
 
-func (m *machine) emitError(s string, args ...interface{}) error {
-	e := fmt.Errorf(s+ColumnPositionTemplate, args...)
+func (m *machine) emitError(messageTemplate string, offender byte, col int) *ParseError {
+	line, column, snippet := lineAndColumn(m.data, col)
+	e := &ParseError{
+		Kind:     kindOf[messageTemplate],
+		Offset:   col,
+		Line:     line,
+		Column:   column,
+		Snippet:  snippet,
+		Expected: expectedOf[messageTemplate],
+		Offender: offender,
+		Message:  messageTemplate,
+	}
+	if m.bestEffort {
+		m.errs = append(m.errs, e)
+	}
 	if m.logger != nil {
 		m.logger.Errorln(e)
 	}
@@ -76,15 +66,23 @@ func (m *machine) emitError(s string, args ...interface{}) error {
 }
 
 func (m *machine) emitErrorWithoutCharacter(messageTemplate string) error {
-	return m.emitError(messageTemplate, m.p)
+	return m.emitError(messageTemplate, 0, m.p)
 }
 
 func (m *machine) emitErrorOnCurrentCharacter(messageTemplate string) error {
-	return m.emitError(messageTemplate, string(m.data[m.p]), m.p)
+	return m.emitError(messageTemplate, m.data[m.p], m.p)
 }
 
 func (m *machine) emitErrorOnPreviousCharacter(messageTemplate string) error {
-	return m.emitError(messageTemplate, string(m.data[m.p-1]), m.p)
+	return m.emitError(messageTemplate, m.data[m.p-1], m.p)
+}
+
+// notifyPosition tells h, if it implements positioner, the absolute offset into the whole input
+// where the text passed to the next SetDescription or AddBodyLine call starts.
+func (m *machine) notifyPosition(h Handler) {
+	if p, ok := h.(positioner); ok {
+		p.setOffset(m.pb)
+	}
 }
 
 // NewMachine creates a new FSM able to parse Conventional Commits.
@@ -105,16 +103,96 @@ func NewMachine(options ...conventionalcommits.MachineOption) conventionalcommit
 //
 // It can also partially parse input messages returning a partially valid structured representation
 // and the error that stopped the parsing.
+//
+// Parse is implemented on top of ParseWithHandler using a handler that fills in a conventionalCommit.
 func (m *machine) Parse(input []byte) (conventionalcommits.Message, error) {
+	output := &conventionalCommit{}
+	h := &defaultHandler{output: output, data: input, encoding: m.encoding, decoder: m.decoder}
+
+	err := m.ParseWithHandler(input, h)
+	if err == nil {
+		err = h.err
+	}
+	output.typeconfig = m.MatchedTypeConfig()
+	if err != nil {
+		if m.bestEffort && output.minimal() {
+			// An error occurred but partial parsing is on and partial message is minimally valid
+			return output.export(), err
+		}
+		return nil, err
+	}
+
+	return output.export(), nil
+}
+
+// ParseWithHandler parses the input byte array as a Conventional Commit message (header and
+// body; footers are not recognized), invoking the given Handler as the machine walks through its states instead of
+// allocating a conventionalCommit.
+//
+// This lets callers avoid the allocation a Message incurs, stop parsing early, reject a message by
+// its type before its description is even read, or route the pieces of the message directly into
+// their own structures.
+//
+// Note that the slim machine does not recognize footers, so AddFooter is never called.
+//
+// When a fallback chain has been configured with WithFallbackChain, the input is tried against
+// each TypeConfig of the chain in order, moving on after any failed attempt (not only a failure
+// of the _type production); the first one under which the whole parse succeeds wins, and
+// MatchedTypeConfig reports which one that was. If every config in the chain fails, the error
+// from the first (strictest) config is returned. If h implements resetter, it is reset before
+// every attempt after the first, so a handler that partially populated itself from a failed
+// attempt does not leak those fields into the one that succeeds - or, in best-effort mode, into
+// the partial message returned alongside an error that describes a different, earlier attempt.
+//
+// When WithBestEffort is on, Errors() accumulates across every attempt in the chain: each config
+// tried contributes the one ParseError that stopped it, so a chain of three configs can surface
+// up to three ParseErrors from a single ParseWithHandler call. If every config fails and more than
+// one ParseError was collected, the returned error is a *MultiError wrapping all of them, rather
+// than just the first (strictest) config's ParseError.
+func (m *machine) ParseWithHandler(input []byte, h Handler) error {
+	chain := m.fallbackChain
+	if len(chain) == 0 {
+		chain = []conventionalcommits.TypeConfig{m.typeConfig}
+	}
+
+	m.errs = nil
+	m.hasMatched = false
+
+	var firstErr error
+	for i, typeConfig := range chain {
+		if i > 0 {
+			if r, ok := h.(resetter); ok {
+				r.reset()
+			}
+		}
+		err := m.parseOnce(input, h, typeConfig)
+		if err == nil {
+			m.matchedTypeConf = typeConfig
+			m.hasMatched = true
+			return nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if m.bestEffort && len(m.errs) > 1 {
+		return &MultiError{Errors: m.errs}
+	}
+
+	return firstErr
+}
+
+// parseOnce runs the FSM once over input under the given typeConfig, invoking h as it goes.
+func (m *machine) parseOnce(input []byte, h Handler, typeConfig conventionalcommits.TypeConfig) error {
 	m.data = input
 	m.p = 0
 	m.pb = 0
 	m.pe = len(input)
 	m.eof = len(input)
 	m.err = nil
-	output := &conventionalCommit{}
 
-	switch m.typeConfig {
+	switch typeConfig {
 	case conventionalcommits.TypesConventional:
 		m.cs = enConventionalTypesMain
 		break
@@ -380,7 +458,7 @@ func (m *machine) Parse(input []byte) (conventionalcommits.Message, error) {
 	tr13:
 
 		if m.p < m.pe && m.data[m.p] == 10 {
-			m.err = m.emitError(ErrNewline, m.p+1)
+			m.err = m.emitError(ErrNewline, 10, m.p+1)
 		} else {
 			m.err = m.emitErrorOnPreviousCharacter(ErrDescription)
 		}
@@ -446,8 +524,11 @@ func (m *machine) Parse(input []byte) (conventionalcommits.Message, error) {
 		}
 	stCase5:
 
-		output._type = string(m.text())
-		m.emitInfo("valid commit message type", "type", output._type)
+		if err := h.SetType(m.text()); err != nil {
+			m.err = err
+			return m.err
+		}
+		m.emitInfo("valid commit message type", "type", string(m.text()))
 
 		switch (m.data)[(m.p)] {
 		case 33:
@@ -460,7 +541,7 @@ func (m *machine) Parse(input []byte) (conventionalcommits.Message, error) {
 		goto tr6
 	tr7:
 
-		output.exclamation = true
+		h.SetBreaking()
 		m.emitInfo("commit message communicates a breaking change")
 
 		goto st6
@@ -525,8 +606,9 @@ func (m *machine) Parse(input []byte) (conventionalcommits.Message, error) {
 		goto st95
 	tr107:
 
-		output.descr = string(m.text())
-		m.emitInfo("valid commit message description", "description", output.descr)
+		m.notifyPosition(h)
+		h.SetDescription(m.text())
+		m.emitInfo("valid commit message description", "description", string(m.text()))
 
 		goto st9
 	st9:
@@ -591,14 +673,14 @@ func (m *machine) Parse(input []byte) (conventionalcommits.Message, error) {
 
 		m.pb = m.p
 
-		output.scope = string(m.text())
-		m.emitInfo("valid commit message scope", "scope", output.scope)
+		h.SetScope(m.text())
+		m.emitInfo("valid commit message scope", "scope", string(m.text()))
 
 		goto st12
 	tr20:
 
-		output.scope = string(m.text())
-		m.emitInfo("valid commit message scope", "scope", output.scope)
+		h.SetScope(m.text())
+		m.emitInfo("valid commit message scope", "scope", string(m.text()))
 
 		goto st12
 	st12:
@@ -699,8 +781,11 @@ func (m *machine) Parse(input []byte) (conventionalcommits.Message, error) {
 		}
 	stCase19:
 
-		output._type = string(m.text())
-		m.emitInfo("valid commit message type", "type", output._type)
+		if err := h.SetType(m.text()); err != nil {
+			m.err = err
+			return m.err
+		}
+		m.emitInfo("valid commit message type", "type", string(m.text()))
 
 		switch (m.data)[(m.p)] {
 		case 33:
@@ -713,7 +798,7 @@ func (m *machine) Parse(input []byte) (conventionalcommits.Message, error) {
 		goto tr6
 	tr33:
 
-		output.exclamation = true
+		h.SetBreaking()
 		m.emitInfo("commit message communicates a breaking change")
 
 		goto st20
@@ -778,8 +863,9 @@ func (m *machine) Parse(input []byte) (conventionalcommits.Message, error) {
 		goto st98
 	tr111:
 
-		output.descr = string(m.text())
-		m.emitInfo("valid commit message description", "description", output.descr)
+		m.notifyPosition(h)
+		h.SetDescription(m.text())
+		m.emitInfo("valid commit message description", "description", string(m.text()))
 
 		goto st23
 	st23:
@@ -844,14 +930,14 @@ func (m *machine) Parse(input []byte) (conventionalcommits.Message, error) {
 
 		m.pb = m.p
 
-		output.scope = string(m.text())
-		m.emitInfo("valid commit message scope", "scope", output.scope)
+		h.SetScope(m.text())
+		m.emitInfo("valid commit message scope", "scope", string(m.text()))
 
 		goto st26
 	tr42:
 
-		output.scope = string(m.text())
-		m.emitInfo("valid commit message scope", "scope", output.scope)
+		h.SetScope(m.text())
+		m.emitInfo("valid commit message scope", "scope", string(m.text()))
 
 		goto st26
 	st26:
@@ -1241,8 +1327,11 @@ func (m *machine) Parse(input []byte) (conventionalcommits.Message, error) {
 		}
 	stCase60:
 
-		output._type = string(m.text())
-		m.emitInfo("valid commit message type", "type", output._type)
+		if err := h.SetType(m.text()); err != nil {
+			m.err = err
+			return m.err
+		}
+		m.emitInfo("valid commit message type", "type", string(m.text()))
 
 		switch (m.data)[(m.p)] {
 		case 33:
@@ -1255,7 +1344,7 @@ func (m *machine) Parse(input []byte) (conventionalcommits.Message, error) {
 		goto tr6
 	tr77:
 
-		output.exclamation = true
+		h.SetBreaking()
 		m.emitInfo("commit message communicates a breaking change")
 
 		goto st61
@@ -1320,8 +1409,9 @@ func (m *machine) Parse(input []byte) (conventionalcommits.Message, error) {
 		goto st101
 	tr115:
 
-		output.descr = string(m.text())
-		m.emitInfo("valid commit message description", "description", output.descr)
+		m.notifyPosition(h)
+		h.SetDescription(m.text())
+		m.emitInfo("valid commit message description", "description", string(m.text()))
 
 		goto st64
 	st64:
@@ -1386,14 +1476,14 @@ func (m *machine) Parse(input []byte) (conventionalcommits.Message, error) {
 
 		m.pb = m.p
 
-		output.scope = string(m.text())
-		m.emitInfo("valid commit message scope", "scope", output.scope)
+		h.SetScope(m.text())
+		m.emitInfo("valid commit message scope", "scope", string(m.text()))
 
 		goto st67
 	tr86:
 
-		output.scope = string(m.text())
-		m.emitInfo("valid commit message scope", "scope", output.scope)
+		h.SetScope(m.text())
+		m.emitInfo("valid commit message scope", "scope", string(m.text()))
 
 		goto st67
 	st67:
@@ -2041,7 +2131,7 @@ func (m *machine) Parse(input []byte) (conventionalcommits.Message, error) {
 			case 8, 22, 63:
 
 				if m.p < m.pe && m.data[m.p] == 10 {
-					m.err = m.emitError(ErrNewline, m.p+1)
+					m.err = m.emitError(ErrNewline, 10, m.p+1)
 				} else {
 					m.err = m.emitErrorOnPreviousCharacter(ErrDescription)
 				}
@@ -2052,20 +2142,23 @@ func (m *machine) Parse(input []byte) (conventionalcommits.Message, error) {
 
 			case 95, 98, 101:
 
-				output.descr = string(m.text())
-				m.emitInfo("valid commit message description", "description", output.descr)
+				m.notifyPosition(h)
+				h.SetDescription(m.text())
+				m.emitInfo("valid commit message description", "description", string(m.text()))
 
 			case 97, 100, 103:
 
-				output.body = string(m.text())
-				m.emitInfo("valid commit message body", "body", output.body)
+				m.notifyPosition(h)
+				h.AddBodyLine(m.text())
+				m.emitInfo("valid commit message body", "body", string(m.text()))
 
 			case 96, 99, 102:
 
 				m.pb = m.p
 
-				output.body = string(m.text())
-				m.emitInfo("valid commit message body", "body", output.body)
+				m.notifyPosition(h)
+				h.AddBodyLine(m.text())
+				m.emitInfo("valid commit message body", "body", string(m.text()))
 
 			case 1, 14, 55:
 
@@ -2088,14 +2181,30 @@ func (m *machine) Parse(input []byte) (conventionalcommits.Message, error) {
 	}
 
 	if m.cs < firstFinal {
-		if m.bestEffort && output.minimal() {
-			// An error occurred but partial parsing is on and partial message is minimally valid
-			return output.export(), m.err
-		}
-		return nil, m.err
+		return m.err
 	}
 
-	return output.export(), nil
+	return nil
+}
+
+// WithFallbackChain tells the parser to try, in order, each of the given TypeConfig upon a failed
+// parse under the previous one, whatever production failed, rewinding back to the start of the
+// input before each retry.
+//
+// When set, it takes precedence over the single TypeConfig set via WithTypes.
+func (m *machine) WithFallbackChain(chain []conventionalcommits.TypeConfig) {
+	m.fallbackChain = chain
+}
+
+// MatchedTypeConfig tells which TypeConfig successfully parsed the last input.
+//
+// If the last Parse or ParseWithHandler call had no fallback chain configured, or every config in
+// the chain failed, this falls back to the single TypeConfig set via WithTypes.
+func (m *machine) MatchedTypeConfig() conventionalcommits.TypeConfig {
+	if m.hasMatched {
+		return m.matchedTypeConf
+	}
+	return m.typeConfig
 }
 
 // WithBestEffort enables best effort mode.
@@ -2108,6 +2217,17 @@ func (m *machine) HasBestEffort() bool {
 	return m.bestEffort
 }
 
+// Errors returns every ParseError collected during the last Parse or ParseWithHandler call made
+// while best effort mode is on. It is nil unless WithBestEffort has been called.
+//
+// A single TypeConfig attempt halts at its first error, since the slim machine has no recovery
+// production to skip past one and keep scanning, so a call with no fallback chain configured
+// contributes at most one ParseError here; see ParseWithHandler for how a fallback chain changes
+// that.
+func (m *machine) Errors() []*ParseError {
+	return m.errs
+}
+
 // WithTypes tells the parser which commit message types to consider.
 func (m *machine) WithTypes(t conventionalcommits.TypeConfig) {
 	m.typeConfig = t
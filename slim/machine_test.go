@@ -0,0 +1,91 @@
+package slim
+
+import (
+	"testing"
+
+	"github.com/leodido/go-conventionalcommits"
+)
+
+func TestParseValid(t *testing.T) {
+	m := &machine{}
+	msg, err := m.Parse([]byte("feat(parser): add streaming support"))
+	if err != nil {
+		t.Fatalf("Parse returned unexpected error: %v", err)
+	}
+
+	cc, ok := msg.(*conventionalcommits.ConventionalCommit)
+	if !ok {
+		t.Fatalf("Parse returned %T, want *conventionalcommits.ConventionalCommit", msg)
+	}
+	if cc.Type != "feat" {
+		t.Errorf("Type = %q, want %q", cc.Type, "feat")
+	}
+	if cc.Scope == nil || *cc.Scope != "parser" {
+		t.Errorf("Scope = %v, want %q", cc.Scope, "parser")
+	}
+	if cc.Description != "add streaming support" {
+		t.Errorf("Description = %q, want %q", cc.Description, "add streaming support")
+	}
+	if cc.Exclamation {
+		t.Error("Exclamation = true, want false")
+	}
+}
+
+func TestParseBreakingChange(t *testing.T) {
+	m := &machine{}
+	msg, err := m.Parse([]byte("feat!: drop support for old config format"))
+	if err != nil {
+		t.Fatalf("Parse returned unexpected error: %v", err)
+	}
+
+	cc := msg.(*conventionalcommits.ConventionalCommit)
+	if !cc.Exclamation {
+		t.Error("Exclamation = false, want true")
+	}
+	if !cc.IsBreakingChange() {
+		t.Error("IsBreakingChange() = false, want true")
+	}
+}
+
+func TestParseMissingColon(t *testing.T) {
+	m := &machine{}
+	_, err := m.Parse([]byte("feat add streaming support"))
+	if err == nil {
+		t.Fatal("Parse returned no error for input missing a colon")
+	}
+
+	pe, ok := err.(*ParseError)
+	if !ok {
+		t.Fatalf("error %v is not a *ParseError", err)
+	}
+	if pe.Kind != KindColon {
+		t.Errorf("Kind = %v, want %v", pe.Kind, KindColon)
+	}
+}
+
+func TestParseBestEffortPartial(t *testing.T) {
+	m := &machine{}
+	m.WithBestEffort()
+
+	msg, err := m.Parse([]byte("feat: add streaming support\n"))
+	if err == nil {
+		t.Fatal("Parse returned no error for input missing a trailing blank line before the body")
+	}
+	if msg == nil {
+		t.Fatal("Parse returned a nil message in best-effort mode despite a minimally valid commit")
+	}
+
+	cc := msg.(*conventionalcommits.ConventionalCommit)
+	if cc.Description != "add streaming support" {
+		t.Errorf("Description = %q, want %q", cc.Description, "add streaming support")
+	}
+}
+
+func TestParseEmptyInput(t *testing.T) {
+	m := &machine{}
+	_, err := m.Parse(nil)
+	if err == nil {
+		t.Fatal("Parse returned no error for empty input")
+	}
+}
+
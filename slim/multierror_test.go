@@ -0,0 +1,53 @@
+package slim
+
+import (
+	"testing"
+
+	"github.com/leodido/go-conventionalcommits"
+)
+
+func TestParseBestEffortWithFallbackChainReturnsMultiError(t *testing.T) {
+	m := &machine{}
+	m.WithBestEffort()
+	m.WithFallbackChain([]conventionalcommits.TypeConfig{
+		conventionalcommits.TypesConventional,
+		conventionalcommits.TypesFalco,
+	})
+
+	// "nonsense" isn't a valid type under either config, so both attempts fail and contribute a
+	// ParseError.
+	_, err := m.Parse([]byte("nonsense: description"))
+	if err == nil {
+		t.Fatal("Parse returned no error for an unrecognized type under every config in the chain")
+	}
+
+	me, ok := err.(*MultiError)
+	if !ok {
+		t.Fatalf("error %v (%T) is not a *MultiError", err, err)
+	}
+	if len(me.Errors) != 2 {
+		t.Fatalf("len(Errors) = %d, want 2 (one per config in the chain)", len(me.Errors))
+	}
+	for _, pe := range me.Errors {
+		if pe.Kind != KindType {
+			t.Errorf("Errors[_].Kind = %v, want %v", pe.Kind, KindType)
+		}
+	}
+}
+
+func TestParseBestEffortWithoutFallbackChainReturnsSingleParseError(t *testing.T) {
+	m := &machine{}
+	m.WithBestEffort()
+
+	_, err := m.Parse([]byte("nonsense: description"))
+	if err == nil {
+		t.Fatal("Parse returned no error for an unrecognized type")
+	}
+
+	if _, ok := err.(*MultiError); ok {
+		t.Fatalf("error is a *MultiError without a fallback chain, want a single *ParseError")
+	}
+	if _, ok := err.(*ParseError); !ok {
+		t.Fatalf("error %v (%T) is not a *ParseError", err, err)
+	}
+}
@@ -0,0 +1,124 @@
+package slim
+
+import (
+	"strings"
+
+	"github.com/leodido/go-conventionalcommits"
+)
+
+// Normalized is the canonical form of a parsed commit message: the type lowercased for
+// comparison, the scope and description with surrounding whitespace trimmed, and the body with
+// line endings normalized to LF and consecutive blank lines collapsed to one.
+//
+// Two commit messages that produce equal Normalized values are lexically equivalent: e.g. "feat"
+// and "Feat" are the same type without relaxing the FSM that requires a specific casing.
+type Normalized struct {
+	Type        string
+	Scope       string
+	Description string
+	Body        string
+	Breaking    bool
+}
+
+// normalize is the shared core both conventionalCommit.Normalize and the package-level Normalize
+// function build their result from.
+//
+// The slim machine does not parse footers, so there is no `BREAKING CHANGE` footer token to fold
+// into its canonical `BREAKING-CHANGE` form here; the `!` exclamation marker is carried over as-is.
+func normalize(typ, scope, descr, body string, breaking bool) Normalized {
+	return Normalized{
+		Type:        strings.ToLower(typ),
+		Scope:       strings.TrimSpace(scope),
+		Description: strings.TrimSpace(descr),
+		Body:        collapseBlankLines(toLF(body)),
+		Breaking:    breaking,
+	}
+}
+
+// Normalize returns the canonical form of c.
+func (c *conventionalCommit) Normalize() Normalized {
+	return normalize(c._type, c.scope, c.descr, c.body, c.exclamation)
+}
+
+// Normalized returns the canonical form of c, see Normalize.
+func (c *conventionalCommit) Normalized() Normalized {
+	return c.Normalize()
+}
+
+// Normalize returns the canonical form of c, the conventionalcommits.ConventionalCommit that Parse
+// and ParseWithHandler's defaultHandler build. Unlike the conventionalCommit.Normalize method
+// above, this is reachable by any caller: Parse returns a conventionalcommits.Message, and a type
+// assertion on the returned value to *conventionalcommits.ConventionalCommit is all a caller needs
+// to call this.
+func Normalize(c *conventionalcommits.ConventionalCommit) Normalized {
+	var scope, body string
+	if c.Scope != nil {
+		scope = *c.Scope
+	}
+	if c.Body != nil {
+		body = *c.Body
+	}
+	return normalize(c.Type, scope, c.Description, body, c.Exclamation)
+}
+
+// Canonical re-emits n as commit message text, stable across commits that only differ in type
+// casing, scope whitespace, line endings, or run of blank lines in the body.
+func (n Normalized) Canonical() string {
+	var b strings.Builder
+	b.WriteString(n.Type)
+	if n.Scope != "" {
+		b.WriteString("(")
+		b.WriteString(n.Scope)
+		b.WriteString(")")
+	}
+	if n.Breaking {
+		b.WriteString("!")
+	}
+	b.WriteString(": ")
+	b.WriteString(n.Description)
+	if n.Body != "" {
+		b.WriteString("\n\n")
+		b.WriteString(n.Body)
+	}
+
+	return b.String()
+}
+
+// Canonical re-emits c in its normalized form as commit message text, see Normalized.Canonical.
+func (c *conventionalCommit) Canonical() string {
+	return c.Normalize().Canonical()
+}
+
+// Canonical re-emits c in its normalized form as commit message text, see Normalized.Canonical.
+func Canonical(c *conventionalcommits.ConventionalCommit) string {
+	return Normalize(c).Canonical()
+}
+
+// Equal reports whether a and b are lexically equivalent conventional commit messages, i.e.
+// whether their normalized forms match.
+//
+// Equal only recognizes messages produced by this package's Parse or ParseWithHandler, i.e. ones
+// whose dynamic type is *conventionalcommits.ConventionalCommit: the conventionalcommits.Message
+// interface itself does not expose the fields normalization needs. Equal returns false, rather
+// than panicking, for a Message of any other concrete type.
+func Equal(a, b conventionalcommits.Message) bool {
+	ac, aok := a.(*conventionalcommits.ConventionalCommit)
+	bc, bok := b.(*conventionalcommits.ConventionalCommit)
+	if !aok || !bok {
+		return false
+	}
+	return Normalize(ac) == Normalize(bc)
+}
+
+// toLF rewrites CRLF line endings to LF.
+func toLF(s string) string {
+	return strings.ReplaceAll(s, "\r\n", "\n")
+}
+
+// collapseBlankLines folds any run of two or more consecutive blank lines down to one.
+func collapseBlankLines(s string) string {
+	for strings.Contains(s, "\n\n\n") {
+		s = strings.ReplaceAll(s, "\n\n\n", "\n\n")
+	}
+	return s
+}
@@ -0,0 +1,78 @@
+package slim
+
+import (
+	"testing"
+
+	"github.com/leodido/go-conventionalcommits"
+)
+
+func mustParse(t *testing.T, input string) *conventionalcommits.ConventionalCommit {
+	t.Helper()
+	m := &machine{}
+	msg, err := m.Parse([]byte(input))
+	if err != nil {
+		t.Fatalf("Parse(%q) returned unexpected error: %v", input, err)
+	}
+	return msg.(*conventionalcommits.ConventionalCommit)
+}
+
+func TestEqualIgnoresScopeCasing(t *testing.T) {
+	a := mustParse(t, "feat(Parser): add streaming support")
+	b := mustParse(t, "feat(parser): add streaming support")
+
+	if !Equal(a, b) {
+		t.Errorf("Equal(%+v, %+v) = false, want true", a, b)
+	}
+}
+
+func TestEqualDistinguishesDifferentDescriptions(t *testing.T) {
+	a := mustParse(t, "feat: add streaming support")
+	b := mustParse(t, "feat: add batching support")
+
+	if Equal(a, b) {
+		t.Errorf("Equal(%+v, %+v) = true, want false", a, b)
+	}
+}
+
+func TestEqualReturnsFalseForNonConventionalCommitMessage(t *testing.T) {
+	a := mustParse(t, "feat: add streaming support")
+
+	if Equal(a, fakeMessage{}) {
+		t.Error("Equal with a non-*ConventionalCommit Message = true, want false")
+	}
+}
+
+type fakeMessage struct{}
+
+func (fakeMessage) Ok() bool               { return true }
+func (fakeMessage) IsBreakingChange() bool { return false }
+func (fakeMessage) IsFeat() bool           { return false }
+func (fakeMessage) IsFix() bool            { return false }
+func (fakeMessage) VersionBump(conventionalcommits.VersionBumpStrategy) conventionalcommits.VersionBump {
+	return 0
+}
+func (fakeMessage) HasFooter() bool { return false }
+
+func TestCanonicalRoundTripsThroughParse(t *testing.T) {
+	cc := mustParse(t, "feat(Parser)!: add streaming support")
+
+	got := Canonical(cc)
+	want := "feat(parser)!: add streaming support"
+	if got != want {
+		t.Errorf("Canonical() = %q, want %q", got, want)
+	}
+}
+
+func TestNormalizeCollapsesBlankLinesAndCRLF(t *testing.T) {
+	n := normalize("feat", " parser ", " add streaming support ", "line one\r\n\r\n\r\nline two", false)
+
+	if n.Body != "line one\n\nline two" {
+		t.Errorf("Body = %q, want %q", n.Body, "line one\n\nline two")
+	}
+	if n.Scope != "parser" {
+		t.Errorf("Scope = %q, want %q", n.Scope, "parser")
+	}
+	if n.Description != "add streaming support" {
+		t.Errorf("Description = %q, want %q", n.Description, "add streaming support")
+	}
+}
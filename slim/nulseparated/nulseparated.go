@@ -0,0 +1,19 @@
+// Package nulseparated provides the slim.Framing that `git log --format=... -z` emits.
+package nulseparated
+
+import "bytes"
+
+// Framing terminates each record with a single NUL byte, the format `git log --format=... -z`
+// uses to separate commits without ambiguity over embedded newlines.
+type Framing struct{}
+
+// Split reports the next NUL-terminated record in data, see slim.Framing.
+func (Framing) Split(data []byte, atEOF bool) (advance int, record []byte, ok bool) {
+	if idx := bytes.IndexByte(data, 0); idx >= 0 {
+		return idx + 1, data[:idx], true
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, true
+	}
+	return 0, nil, false
+}
@@ -0,0 +1,49 @@
+package slim
+
+import (
+	"strings"
+
+	"github.com/leodido/go-conventionalcommits"
+)
+
+// conventionalCommit is the defaultHandler's scratch representation of a commit message while it
+// is being built up field by field; export converts it to the conventionalcommits.ConventionalCommit
+// that Parse actually returns. It mirrors the shape the upstream parser package uses for the same
+// purpose, minus footers, which the slim machine never recognizes.
+type conventionalCommit struct {
+	_type       string
+	descr       string
+	scope       string
+	exclamation bool
+	body        string
+	typeconfig  conventionalcommits.TypeConfig
+}
+
+// minimal reports whether c has at least a type and a description, the bar WithBestEffort holds a
+// partial parse to before Parse will return it alongside the error that stopped parsing.
+func (c *conventionalCommit) minimal() bool {
+	return c._type != "" && c.descr != ""
+}
+
+// export converts c to the conventionalcommits.ConventionalCommit Parse returns, lowercasing type
+// and scope the same way the upstream parser package does so "feat" and "Feat" export identically.
+func (c *conventionalCommit) export() conventionalcommits.Message {
+	out := &conventionalcommits.ConventionalCommit{}
+	out.Exclamation = c.exclamation
+	out.Type = strings.ToLower(c._type)
+	out.Description = c.descr
+	out.TypeConfig = c.typeconfig
+	if c.scope != "" {
+		scope := strings.ToLower(c.scope)
+		out.Scope = &scope
+	}
+	if c.body != "" {
+		body := c.body
+		if len(body) >= 2 && body[len(body)-2:] == "\n\n" {
+			body = body[:len(body)-2]
+		}
+		out.Body = &body
+	}
+
+	return out
+}
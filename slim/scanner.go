@@ -0,0 +1,106 @@
+package slim
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+
+	"github.com/leodido/go-conventionalcommits"
+)
+
+// DefaultScannerDelimiter is the delimiter Scanner uses when none is given to NewScanner.
+//
+// It matches the NUL-byte framing that `git log --format=... -z` emits.
+var DefaultScannerDelimiter = []byte("\x00")
+
+// Scanner reads a stream of Conventional Commit messages separated by a delimiter, analogous to
+// bufio.Scanner but yielding parsed conventionalcommits.Message values instead of raw tokens.
+//
+// It reuses a single machine across records instead of allocating a new FSM per message: Parse
+// reinitializes the machine's position fields (m.p, m.pb, m.pe) and m.err on every call, so the
+// same *machine can safely drive record after record.
+type Scanner struct {
+	r     *bufio.Reader
+	delim []byte
+	m     *machine
+	msg   conventionalcommits.Message
+	err   error
+	done  bool
+}
+
+// NewScanner creates a Scanner that reads records from r separated by delim.
+//
+// If delim is empty it defaults to DefaultScannerDelimiter. The given options configure the
+// underlying machine exactly as they would NewMachine.
+func NewScanner(r io.Reader, delim []byte, opts ...conventionalcommits.MachineOption) *Scanner {
+	if len(delim) == 0 {
+		delim = DefaultScannerDelimiter
+	}
+
+	m := &machine{}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return &Scanner{
+		r:     bufio.NewReader(r),
+		delim: delim,
+		m:     m,
+	}
+}
+
+// Scan advances the Scanner to the next record and parses it.
+//
+// It returns false once the stream is exhausted or a read error (other than io.EOF) occurs; that
+// read error, if any, is available via Err(). A per-record parse error does not stop the stream:
+// Scan still returns true for that record, the error is available via Err(), and the following
+// Scan call proceeds to the next record.
+func (s *Scanner) Scan() bool {
+	if s.done {
+		return false
+	}
+
+	record, readErr := s.readRecord()
+	if len(record) == 0 && readErr != nil {
+		s.done = true
+		s.err = nil
+		if readErr != io.EOF {
+			s.err = readErr
+		}
+		return false
+	}
+
+	s.msg, s.err = s.m.Parse(record)
+
+	if readErr == io.EOF {
+		s.done = true
+	}
+
+	return true
+}
+
+// Message returns the Message parsed by the most recent call to Scan.
+func (s *Scanner) Message() conventionalcommits.Message {
+	return s.msg
+}
+
+// Err returns the error, if any, produced while parsing the most recent record.
+//
+// It does not indicate whether more records are available: check the return value of Scan for that.
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+func (s *Scanner) readRecord() ([]byte, error) {
+	var record []byte
+	for {
+		chunk, err := s.r.ReadBytes(s.delim[len(s.delim)-1])
+		record = append(record, chunk...)
+		if bytes.HasSuffix(record, s.delim) {
+			return bytes.TrimSuffix(record, s.delim), nil
+		}
+		if err != nil {
+			return record, err
+		}
+	}
+}
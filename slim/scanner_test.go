@@ -0,0 +1,67 @@
+package slim
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/leodido/go-conventionalcommits"
+)
+
+func TestScannerScansDelimitedRecords(t *testing.T) {
+	input := "feat: add streaming support\x00fix: correct off-by-one\x00"
+	s := NewScanner(strings.NewReader(input), nil)
+
+	var types []string
+	for s.Scan() {
+		if err := s.Err(); err != nil {
+			t.Fatalf("unexpected per-record error: %v", err)
+		}
+		types = append(types, s.Message().(*conventionalcommits.ConventionalCommit).Type)
+	}
+	if err := s.Err(); err != nil {
+		t.Fatalf("Scan stopped with unexpected error: %v", err)
+	}
+
+	want := []string{"feat", "fix"}
+	if len(types) != len(want) {
+		t.Fatalf("scanned %d records, want %d", len(types), len(want))
+	}
+	for i, typ := range want {
+		if types[i] != typ {
+			t.Errorf("record %d Type = %q, want %q", i, types[i], typ)
+		}
+	}
+}
+
+func TestScannerSurvivesPerRecordParseError(t *testing.T) {
+	input := "not a commit\x00feat: add streaming support\x00"
+	s := NewScanner(strings.NewReader(input), nil)
+
+	if !s.Scan() {
+		t.Fatal("Scan() = false on first record, want true")
+	}
+	if s.Err() == nil {
+		t.Fatal("Err() = nil for a malformed first record, want an error")
+	}
+
+	if !s.Scan() {
+		t.Fatal("Scan() = false on second record, want true")
+	}
+	if err := s.Err(); err != nil {
+		t.Fatalf("Err() = %v for a valid second record, want nil", err)
+	}
+
+	if s.Scan() {
+		t.Fatal("Scan() = true after the stream is exhausted, want false")
+	}
+}
+
+func TestScannerDefaultsDelimiterWhenEmpty(t *testing.T) {
+	s := NewScanner(strings.NewReader("feat: add streaming support\x00"), nil)
+	if !s.Scan() {
+		t.Fatal("Scan() = false, want true")
+	}
+	if err := s.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
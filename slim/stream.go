@@ -0,0 +1,116 @@
+package slim
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+
+	"github.com/leodido/go-conventionalcommits"
+)
+
+// Framing determines how StreamParser splits a stream of bytes into individual commit message
+// records. Implementations live in framing-specific subpackages (nulseparated,
+// blanklineseparated), selected at construction time via WithFraming, the same shape
+// influxdata/go-syslog v3 uses for its octetcounting and nontransparent parsers.
+//
+// Split has the same contract as bufio.SplitFunc minus the error return: given the unconsumed,
+// buffered prefix of the stream, it reports how many bytes make up the next complete record
+// (without its trailing delimiter, if any) and how many bytes to advance past altogether (that
+// record plus its delimiter). It returns ok = false when data does not yet contain a complete
+// record and more input is needed, unless atEOF is true, in which case any remaining data is the
+// final record.
+//
+// A fixed delimiter is not enough for every framing: blanklineseparated needs to look past the
+// delimiter to tell a genuine record boundary from a blank line inside a commit's own body, which
+// a bare Delimiter() []byte could not express.
+type Framing interface {
+	Split(data []byte, atEOF bool) (advance int, record []byte, ok bool)
+}
+
+// defaultFraming is NewStreamParser's default: the NUL-byte framing `git log --format=... -z`
+// emits, byte-for-byte the same as nulseparated.Framing. It is unexported so the common case does
+// not require importing that subpackage just to get the default.
+type defaultFraming struct{}
+
+func (defaultFraming) Split(data []byte, atEOF bool) (advance int, record []byte, ok bool) {
+	if idx := bytes.IndexByte(data, 0); idx >= 0 {
+		return idx + 1, data[:idx], true
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, true
+	}
+	return 0, nil, false
+}
+
+// StreamParser reads a possibly large stream of framed commit messages and parses them one at a
+// time via Next, without loading the whole stream into memory.
+type StreamParser struct {
+	sc *bufio.Scanner
+	m  *machine
+}
+
+// StreamParserOption configures a StreamParser at construction time.
+type StreamParserOption func(*streamParserConfig)
+
+type streamParserConfig struct {
+	framing        Framing
+	machineOptions []conventionalcommits.MachineOption
+}
+
+// WithFraming selects the Framing a StreamParser splits records on, e.g. nulseparated.Framing{}
+// (the default) or blanklineseparated.Framing{}, or a caller's own Framing implementation.
+func WithFraming(f Framing) StreamParserOption {
+	return func(c *streamParserConfig) {
+		c.framing = f
+	}
+}
+
+// WithMachineOptions configures the underlying machine exactly as they would NewMachine.
+func WithMachineOptions(opts ...conventionalcommits.MachineOption) StreamParserOption {
+	return func(c *streamParserConfig) {
+		c.machineOptions = opts
+	}
+}
+
+// NewStreamParser creates a StreamParser reading framed commit messages from r.
+func NewStreamParser(r io.Reader, opts ...StreamParserOption) *StreamParser {
+	c := &streamParserConfig{
+		framing: defaultFraming{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	m := &machine{}
+	for _, opt := range c.machineOptions {
+		opt(m)
+	}
+
+	sc := bufio.NewScanner(r)
+	sc.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, record, ok := c.framing.Split(data, atEOF)
+		if !ok {
+			return 0, nil, nil
+		}
+		return advance, record, nil
+	})
+
+	return &StreamParser{sc: sc, m: m}
+}
+
+// Next parses and returns the next record in the stream.
+//
+// It returns io.EOF once the stream is exhausted. A parse error on a given record is returned
+// alongside whatever Parse yields for that record (a partial Message in best-effort mode when one
+// is minimally valid, nil otherwise) and does not stop the stream: the next call to Next proceeds
+// to the following record.
+func (p *StreamParser) Next() (conventionalcommits.Message, error) {
+	if !p.sc.Scan() {
+		if err := p.sc.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+
+	return p.m.Parse(p.sc.Bytes())
+}
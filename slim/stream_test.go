@@ -0,0 +1,108 @@
+package slim
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/leodido/go-conventionalcommits"
+	"github.com/lewismiddleton/go-conventionalcommits/slim/blanklineseparated"
+	"github.com/lewismiddleton/go-conventionalcommits/slim/nulseparated"
+)
+
+func TestStreamParserDefaultsToNulSeparatedFraming(t *testing.T) {
+	p := NewStreamParser(strings.NewReader("feat: add streaming support\x00fix: correct off-by-one\x00"))
+
+	msg, err := p.Next()
+	if err != nil {
+		t.Fatalf("Next() returned unexpected error: %v", err)
+	}
+	if got := msg.(*conventionalcommits.ConventionalCommit).Type; got != "feat" {
+		t.Errorf("Type = %q, want %q", got, "feat")
+	}
+
+	msg, err = p.Next()
+	if err != nil {
+		t.Fatalf("Next() returned unexpected error: %v", err)
+	}
+	if got := msg.(*conventionalcommits.ConventionalCommit).Type; got != "fix" {
+		t.Errorf("Type = %q, want %q", got, "fix")
+	}
+
+	if _, err := p.Next(); !errors.Is(err, io.EOF) {
+		t.Errorf("Next() after the last record = %v, want io.EOF", err)
+	}
+}
+
+func TestStreamParserWithNulseparatedFraming(t *testing.T) {
+	p := NewStreamParser(
+		strings.NewReader("feat: add streaming support\x00"),
+		WithFraming(nulseparated.Framing{}),
+	)
+
+	msg, err := p.Next()
+	if err != nil {
+		t.Fatalf("Next() returned unexpected error: %v", err)
+	}
+	if got := msg.(*conventionalcommits.ConventionalCommit).Type; got != "feat" {
+		t.Errorf("Type = %q, want %q", got, "feat")
+	}
+}
+
+func TestStreamParserWithBlankLineSeparatedFraming(t *testing.T) {
+	p := NewStreamParser(
+		strings.NewReader("feat: add streaming support\n\nfix: correct off-by-one\n\n"),
+		WithFraming(blanklineseparated.Framing{}),
+	)
+
+	msg, err := p.Next()
+	if err != nil {
+		t.Fatalf("Next() returned unexpected error: %v", err)
+	}
+	if got := msg.(*conventionalcommits.ConventionalCommit).Type; got != "feat" {
+		t.Errorf("Type = %q, want %q", got, "feat")
+	}
+
+	msg, err = p.Next()
+	if err != nil {
+		t.Fatalf("Next() returned unexpected error: %v", err)
+	}
+	if got := msg.(*conventionalcommits.ConventionalCommit).Type; got != "fix" {
+		t.Errorf("Type = %q, want %q", got, "fix")
+	}
+}
+
+// TestStreamParserWithBlankLineSeparatedFramingKeepsBodyWithItsHeader guards against treating the
+// blank line between a description and its body as a record boundary: that would strip the body
+// off the first record and leave the orphaned body text to fail parsing as a bogus second record.
+func TestStreamParserWithBlankLineSeparatedFramingKeepsBodyWithItsHeader(t *testing.T) {
+	p := NewStreamParser(
+		strings.NewReader("feat: add streaming support\n\nThis is the body of the commit.\n\nfix: correct off-by-one\n\n"),
+		WithFraming(blanklineseparated.Framing{}),
+	)
+
+	msg, err := p.Next()
+	if err != nil {
+		t.Fatalf("Next() returned unexpected error: %v", err)
+	}
+	cc := msg.(*conventionalcommits.ConventionalCommit)
+	if cc.Type != "feat" {
+		t.Errorf("Type = %q, want %q", cc.Type, "feat")
+	}
+	if cc.Body == nil || *cc.Body != "This is the body of the commit." {
+		t.Errorf("Body = %v, want %q", cc.Body, "This is the body of the commit.")
+	}
+
+	msg, err = p.Next()
+	if err != nil {
+		t.Fatalf("Next() returned unexpected error: %v", err)
+	}
+	if got := msg.(*conventionalcommits.ConventionalCommit).Type; got != "fix" {
+		t.Errorf("Type = %q, want %q", got, "fix")
+	}
+
+	if _, err := p.Next(); !errors.Is(err, io.EOF) {
+		t.Errorf("Next() after the last record = %v, want io.EOF", err)
+	}
+}